@@ -0,0 +1,179 @@
+// Package client implements ykush.Device against the REST API hosted by
+// ykush3/server, so callers can control a remote YKUSH device the same
+// way they would a local one.
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/fcjr/ykush3"
+)
+
+// Device is a remote YKUSH device reached over HTTP. It implements
+// ykush.Device.
+type Device struct {
+	baseURL string
+	serial  string
+	model   string
+	ports   []ykush.Port
+	http    *http.Client
+}
+
+// Dial connects to the ykush3/server REST API at baseURL and returns a
+// Device for the given serial number.
+func Dial(baseURL, serial string) (*Device, error) {
+	return DialWithClient(http.DefaultClient, baseURL, serial)
+}
+
+// DialWithClient is like Dial but allows callers to supply a custom
+// *http.Client, e.g. with timeouts or TLS configuration.
+func DialWithClient(httpClient *http.Client, baseURL, serial string) (*Device, error) {
+	d := &Device{baseURL: baseURL, serial: serial, http: httpClient}
+
+	var summary struct {
+		Serial string `json:"serial"`
+		Model  string `json:"model"`
+	}
+	found := false
+
+	var all []struct {
+		Serial string `json:"serial"`
+		Model  string `json:"model"`
+	}
+	if err := d.getJSON(context.Background(), "/devices", &all); err != nil {
+		return nil, err
+	}
+	for _, dev := range all {
+		if dev.Serial == serial {
+			summary = dev
+			found = true
+			break
+		}
+	}
+	if !found {
+		return nil, fmt.Errorf("remote server has no device with serial %q", serial)
+	}
+	d.model = summary.Model
+
+	var ports []struct {
+		Port  int  `json:"port"`
+		State bool `json:"state"`
+	}
+	if err := d.getJSON(context.Background(), fmt.Sprintf("/devices/%s/ports", serial), &ports); err != nil {
+		return nil, err
+	}
+	for _, p := range ports {
+		d.ports = append(d.ports, ykush.Port(p.Port))
+	}
+
+	return d, nil
+}
+
+// Model returns the human-readable name of the remote device's model.
+func (d *Device) Model() string { return d.model }
+
+// Ports returns the set of ports the remote device exposes.
+func (d *Device) Ports() []ykush.Port { return d.ports }
+
+// PortUp turns on the specified USB port.
+func (d *Device) PortUp(port ykush.Port) error {
+	return d.PortUpCtx(context.Background(), port)
+}
+
+// PortUpCtx is like PortUp but aborts with ctx.Err() if ctx is done before
+// the server responds.
+func (d *Device) PortUpCtx(ctx context.Context, port ykush.Port) error {
+	return d.setPortStateCtx(ctx, port, "on")
+}
+
+// PortDown turns off the specified USB port.
+func (d *Device) PortDown(port ykush.Port) error {
+	return d.PortDownCtx(context.Background(), port)
+}
+
+// PortDownCtx is like PortDown but aborts with ctx.Err() if ctx is done
+// before the server responds.
+func (d *Device) PortDownCtx(ctx context.Context, port ykush.Port) error {
+	return d.setPortStateCtx(ctx, port, "off")
+}
+
+// GetPortState returns the current on/off state of the specified USB port.
+func (d *Device) GetPortState(port ykush.Port) (ykush.PortState, error) {
+	return d.GetPortStateCtx(context.Background(), port)
+}
+
+// GetPortStateCtx is like GetPortState but aborts with ctx.Err() if ctx is
+// done before the server responds.
+func (d *Device) GetPortStateCtx(ctx context.Context, port ykush.Port) (ykush.PortState, error) {
+	var ports []struct {
+		Port  int  `json:"port"`
+		State bool `json:"state"`
+	}
+	if err := d.getJSON(ctx, fmt.Sprintf("/devices/%s/ports", d.serial), &ports); err != nil {
+		return ykush.PortOff, err
+	}
+	for _, p := range ports {
+		if ykush.Port(p.Port) == port {
+			return ykush.PortState(p.State), nil
+		}
+	}
+	return ykush.PortOff, fmt.Errorf("remote device has no port %v", port)
+}
+
+// Close releases the underlying HTTP connections. The remote device
+// itself is left as-is; Close does not power anything off.
+func (d *Device) Close() error {
+	d.http.CloseIdleConnections()
+	return nil
+}
+
+func (d *Device) setPortStateCtx(ctx context.Context, port ykush.Port, state string) error {
+	body, err := json.Marshal(struct {
+		State string `json:"state"`
+	}{State: state})
+	if err != nil {
+		return err
+	}
+
+	url := fmt.Sprintf("%s/devices/%s/ports/%d", d.baseURL, d.serial, int(port))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := d.http.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to set port state: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusNoContent {
+		return fmt.Errorf("server returned %s", resp.Status)
+	}
+	return nil
+}
+
+func (d *Device) getJSON(ctx context.Context, path string, v interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, d.baseURL+path, nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := d.http.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to reach server: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("server returned %s", resp.Status)
+	}
+	return json.NewDecoder(resp.Body).Decode(v)
+}
+
+var _ ykush.Device = (*Device)(nil)
@@ -0,0 +1,106 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sstallion/go-hid"
+
+	"github.com/fcjr/ykush3"
+)
+
+func runCycle(args []string) error {
+	fs := flag.NewFlagSet("cycle", flag.ExitOnError)
+	serial := fs.String("s", "", "serial number of the device to use")
+	portFlag := fs.String("p", "", "port: 1, 2, 3, or \"all\"")
+	off := fs.Duration("off", 2*time.Second, "how long to leave the port off")
+	settle := fs.Duration("settle", 0, "extra delay after the port is back on, before --wait-vid is checked")
+	waitVID := fs.String("wait-vid", "", "VID:PID (hex) of the downstream device to wait for re-enumerating")
+	waitTimeout := fs.Duration("wait-timeout", 10*time.Second, "how long to wait for --wait-vid before giving up")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *portFlag == "" {
+		return fmt.Errorf("-p is required")
+	}
+	port, err := parsePort(*portFlag)
+	if err != nil {
+		return err
+	}
+
+	dev, err := openDevice(*serial)
+	if err != nil {
+		return err
+	}
+	defer dev.Close()
+
+	opts := ykush.PowerCycleOptions{OffDuration: *off, SettleDuration: *settle}
+
+	if *waitVID != "" {
+		vid, pid, err := parseVIDPID(*waitVID)
+		if err != nil {
+			return err
+		}
+		opts.WaitFor = func(ctx context.Context) error {
+			ctx, cancel := context.WithTimeout(ctx, *waitTimeout)
+			defer cancel()
+			return waitForDevice(ctx, vid, pid)
+		}
+	}
+
+	return ykush.PowerCycle(dev, port, opts)
+}
+
+// parseVIDPID parses a "VID:PID" string where both halves are hex, e.g.
+// "1234:5678".
+func parseVIDPID(s string) (vid, pid uint16, err error) {
+	parts := strings.SplitN(s, ":", 2)
+	if len(parts) != 2 {
+		return 0, 0, fmt.Errorf("invalid --wait-vid %q, want VID:PID in hex", s)
+	}
+	v, err := strconv.ParseUint(parts[0], 16, 16)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid VID %q: %w", parts[0], err)
+	}
+	p, err := strconv.ParseUint(parts[1], 16, 16)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid PID %q: %w", parts[1], err)
+	}
+	return uint16(v), uint16(p), nil
+}
+
+// waitForDevice polls hid.Enumerate until a device matching vid/pid shows
+// up or ctx is done.
+func waitForDevice(ctx context.Context, vid, pid uint16) error {
+	ticker := time.NewTicker(200 * time.Millisecond)
+	defer ticker.Stop()
+
+	check := func() (bool, error) {
+		found := false
+		err := hid.Enumerate(vid, pid, func(info *hid.DeviceInfo) error {
+			found = true
+			return nil
+		})
+		return found, err
+	}
+
+	for {
+		found, err := check()
+		if err != nil {
+			return err
+		}
+		if found {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("timed out waiting for %04x:%04x to re-enumerate: %w", vid, pid, ctx.Err())
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,33 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/fcjr/ykush3"
+)
+
+// parsePort parses a port flag value of "1", "2", "3", or "all".
+func parsePort(s string) (ykush.Port, error) {
+	switch s {
+	case "1":
+		return ykush.Port1, nil
+	case "2":
+		return ykush.Port2, nil
+	case "3":
+		return ykush.Port3, nil
+	case "all":
+		return ykush.AllPorts, nil
+	default:
+		return 0, fmt.Errorf("invalid port %q, want 1, 2, 3, or \"all\"", s)
+	}
+}
+
+// openDevice opens the device with the given serial, or the first
+// available device if serial is empty.
+func openDevice(serial string) (ykush.Device, error) {
+	dev, err := ykush.NewWithSerial(serial)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open device: %w", err)
+	}
+	return dev, nil
+}
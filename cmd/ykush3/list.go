@@ -0,0 +1,44 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/fcjr/ykush3"
+)
+
+func runList(args []string) error {
+	fs := flag.NewFlagSet("list", flag.ExitOnError)
+	jsonOut := fs.Bool("json", false, "print machine-readable JSON output")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	devices, err := ykush.ListDevices()
+	if err != nil {
+		return err
+	}
+
+	if *jsonOut {
+		type entry struct {
+			Serial string `json:"serial"`
+			Model  string `json:"model"`
+		}
+		entries := make([]entry, 0, len(devices))
+		for _, d := range devices {
+			entries = append(entries, entry{Serial: d.SerialNbr, Model: d.Model.String()})
+		}
+		return json.NewEncoder(os.Stdout).Encode(entries)
+	}
+
+	if len(devices) == 0 {
+		fmt.Println("no YKUSH devices found")
+		return nil
+	}
+	for _, d := range devices {
+		fmt.Printf("%s\t%s\n", d.SerialNbr, d.Model)
+	}
+	return nil
+}
@@ -0,0 +1,61 @@
+// Command ykush3 is a scriptable command-line interface to locally
+// attached YKUSH devices, mirroring Yepkit's reference utility.
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+)
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+		os.Exit(2)
+	}
+
+	var err error
+	switch os.Args[1] {
+	case "list":
+		err = runList(os.Args[2:])
+	case "up":
+		err = runPortCmd("up", os.Args[2:])
+	case "down":
+		err = runPortCmd("down", os.Args[2:])
+	case "status":
+		err = runPortCmd("status", os.Args[2:])
+	case "cycle":
+		err = runCycle(os.Args[2:])
+	case "watch":
+		err = runWatch(os.Args[2:])
+	case "-h", "--help", "help":
+		usage()
+		return
+	default:
+		fmt.Fprintf(os.Stderr, "ykush3: unknown command %q\n", os.Args[1])
+		usage()
+		os.Exit(2)
+	}
+
+	if err != nil {
+		if !errors.Is(err, errPortOff) {
+			fmt.Fprintf(os.Stderr, "ykush3: %v\n", err)
+		}
+		os.Exit(1)
+	}
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, `usage: ykush3 <command> [flags]
+
+commands:
+  list                          list connected YKUSH devices
+  up    [-s SERIAL] -p PORT     turn a port on
+  down  [-s SERIAL] -p PORT     turn a port off
+  status[-s SERIAL] -p PORT     print a port's state (non-zero exit if off)
+  cycle [-s SERIAL] -p PORT     power-cycle a port
+  watch                         print attach/detach events as they happen
+
+PORT is 1, 2, 3, or "all". list, status, and watch accept --json for
+machine-readable output.`)
+}
@@ -0,0 +1,90 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/fcjr/ykush3"
+)
+
+// errPortOff signals a clean, already-reported exit(1) for "status" when a
+// port is off, so main doesn't also print an "ykush3: ..." error line for
+// it.
+var errPortOff = errors.New("port is off")
+
+func runPortCmd(name string, args []string) error {
+	fs := flag.NewFlagSet(name, flag.ExitOnError)
+	serial := fs.String("s", "", "serial number of the device to use")
+	portFlag := fs.String("p", "", "port: 1, 2, 3, or \"all\"")
+	jsonOut := fs.Bool("json", false, "print machine-readable JSON output")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *portFlag == "" {
+		return fmt.Errorf("-p is required")
+	}
+	port, err := parsePort(*portFlag)
+	if err != nil {
+		return err
+	}
+
+	dev, err := openDevice(*serial)
+	if err != nil {
+		return err
+	}
+	defer dev.Close()
+
+	switch name {
+	case "up":
+		return dev.PortUp(port)
+	case "down":
+		return dev.PortDown(port)
+	case "status":
+		return runStatus(dev, port, *jsonOut)
+	default:
+		return fmt.Errorf("unknown port command %q", name)
+	}
+}
+
+func runStatus(dev ykush.Device, port ykush.Port, jsonOut bool) error {
+	ports := []ykush.Port{port}
+	if port == ykush.AllPorts {
+		ports = dev.Ports()
+	}
+
+	type entry struct {
+		Port  int    `json:"port"`
+		State string `json:"state"`
+	}
+
+	var entries []entry
+	anyOff := false
+	for _, p := range ports {
+		state, err := dev.GetPortState(p)
+		if err != nil {
+			return err
+		}
+		if state == ykush.PortOff {
+			anyOff = true
+		}
+		entries = append(entries, entry{Port: int(p), State: state.String()})
+	}
+
+	if jsonOut {
+		if err := json.NewEncoder(os.Stdout).Encode(entries); err != nil {
+			return err
+		}
+	} else {
+		for _, e := range entries {
+			fmt.Printf("port %d: %s\n", e.Port, e.State)
+		}
+	}
+
+	if anyOff {
+		return errPortOff
+	}
+	return nil
+}
@@ -0,0 +1,152 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+	"testing"
+
+	"github.com/fcjr/ykush3"
+)
+
+// fakeDevice is a minimal ykush.Device for exercising CLI command logic
+// without real hardware.
+type fakeDevice struct {
+	state map[ykush.Port]ykush.PortState
+}
+
+func newFakeDevice(states map[ykush.Port]ykush.PortState) *fakeDevice {
+	return &fakeDevice{state: states}
+}
+
+func (d *fakeDevice) PortUp(port ykush.Port) error   { d.state[port] = ykush.PortOn; return nil }
+func (d *fakeDevice) PortDown(port ykush.Port) error { d.state[port] = ykush.PortOff; return nil }
+
+func (d *fakeDevice) PortUpCtx(ctx context.Context, port ykush.Port) error   { return d.PortUp(port) }
+func (d *fakeDevice) PortDownCtx(ctx context.Context, port ykush.Port) error { return d.PortDown(port) }
+
+func (d *fakeDevice) GetPortState(port ykush.Port) (ykush.PortState, error) {
+	return d.state[port], nil
+}
+
+func (d *fakeDevice) GetPortStateCtx(ctx context.Context, port ykush.Port) (ykush.PortState, error) {
+	return d.GetPortState(port)
+}
+
+func (d *fakeDevice) Ports() []ykush.Port {
+	ports := make([]ykush.Port, 0, len(d.state))
+	for p := range d.state {
+		ports = append(ports, p)
+	}
+	return ports
+}
+func (d *fakeDevice) Model() string { return "fake" }
+func (d *fakeDevice) Close() error  { return nil }
+
+var _ ykush.Device = (*fakeDevice)(nil)
+
+func TestParsePort(t *testing.T) {
+	cases := []struct {
+		in      string
+		want    ykush.Port
+		wantErr bool
+	}{
+		{"1", ykush.Port1, false},
+		{"2", ykush.Port2, false},
+		{"3", ykush.Port3, false},
+		{"all", ykush.AllPorts, false},
+		{"4", 0, true},
+		{"", 0, true},
+	}
+
+	for _, tc := range cases {
+		got, err := parsePort(tc.in)
+		if tc.wantErr {
+			if err == nil {
+				t.Errorf("parsePort(%q): expected an error", tc.in)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("parsePort(%q): %v", tc.in, err)
+			continue
+		}
+		if got != tc.want {
+			t.Errorf("parsePort(%q) = %v, want %v", tc.in, got, tc.want)
+		}
+	}
+}
+
+func captureStdout(t *testing.T, fn func()) string {
+	t.Helper()
+
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatalf("os.Pipe: %v", err)
+	}
+	orig := os.Stdout
+	os.Stdout = w
+	defer func() { os.Stdout = orig }()
+
+	fn()
+
+	w.Close()
+	var buf bytes.Buffer
+	if _, err := io.Copy(&buf, r); err != nil {
+		t.Fatalf("io.Copy: %v", err)
+	}
+	return buf.String()
+}
+
+func TestRunStatusAllPortsOn(t *testing.T) {
+	dev := newFakeDevice(map[ykush.Port]ykush.PortState{ykush.Port1: ykush.PortOn})
+
+	var err error
+	out := captureStdout(t, func() {
+		err = runStatus(dev, ykush.Port1, false)
+	})
+	if err != nil {
+		t.Fatalf("runStatus: %v", err)
+	}
+	if out != "port 1: ON\n" {
+		t.Fatalf("runStatus output = %q", out)
+	}
+}
+
+func TestRunStatusReturnsErrPortOffWhenAnyOff(t *testing.T) {
+	dev := newFakeDevice(map[ykush.Port]ykush.PortState{ykush.Port1: ykush.PortOff})
+
+	var err error
+	captureStdout(t, func() {
+		err = runStatus(dev, ykush.Port1, false)
+	})
+	if !errors.Is(err, errPortOff) {
+		t.Fatalf("expected errPortOff, got %v", err)
+	}
+}
+
+func TestRunStatusJSONOutput(t *testing.T) {
+	dev := newFakeDevice(map[ykush.Port]ykush.PortState{ykush.Port1: ykush.PortOn})
+
+	var err error
+	out := captureStdout(t, func() {
+		err = runStatus(dev, ykush.Port1, true)
+	})
+	if err != nil {
+		t.Fatalf("runStatus: %v", err)
+	}
+
+	var entries []struct {
+		Port  int    `json:"port"`
+		State string `json:"state"`
+	}
+	if decErr := json.Unmarshal([]byte(out), &entries); decErr != nil {
+		t.Fatalf("decode JSON output %q: %v", out, decErr)
+	}
+	if len(entries) != 1 || entries[0].Port != int(ykush.Port1) || entries[0].State != "ON" {
+		t.Fatalf("runStatus JSON output = %#v", entries)
+	}
+}
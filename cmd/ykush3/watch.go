@@ -0,0 +1,45 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/fcjr/ykush3"
+)
+
+func runWatch(args []string) error {
+	fs := flag.NewFlagSet("watch", flag.ExitOnError)
+	jsonOut := fs.Bool("json", false, "print machine-readable JSON output")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	events, err := ykush.NewWatcher(ctx)
+	if err != nil {
+		return err
+	}
+
+	for ev := range events {
+		if *jsonOut {
+			entry := struct {
+				Event  string `json:"event"`
+				Serial string `json:"serial"`
+				Model  string `json:"model"`
+			}{Event: ev.Type.String(), Serial: ev.Serial, Model: ev.Info.Model.String()}
+			if err := json.NewEncoder(os.Stdout).Encode(entry); err != nil {
+				return err
+			}
+			continue
+		}
+		fmt.Printf("%s\t%s\t%s\n", ev.Type, ev.Serial, ev.Info.Model)
+	}
+	return nil
+}
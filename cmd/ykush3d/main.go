@@ -0,0 +1,66 @@
+// Command ykush3d hosts every YKUSH device attached to this machine behind
+// a REST API and a gRPC service, so they can be shared by remote callers,
+// e.g. CI runners that don't have direct USB access to the lab host.
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+	"net"
+	"net/http"
+	"os/signal"
+	"syscall"
+
+	"google.golang.org/grpc"
+
+	"github.com/fcjr/ykush3"
+	"github.com/fcjr/ykush3/proto/ykushpb"
+	"github.com/fcjr/ykush3/server"
+)
+
+func main() {
+	httpAddr := flag.String("http", ":8420", "address to serve the REST API on")
+	grpcAddr := flag.String("grpc", ":8421", "address to serve the gRPC service on")
+	flag.Parse()
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	srv := server.New()
+
+	mgr, err := ykush.NewManager(ctx,
+		ykush.WithOnAttach(func(serial string, dev ykush.Device) { srv.Add(serial, dev) }),
+		ykush.WithOnDetach(func(serial string, dev ykush.Device) { srv.Remove(serial) }),
+	)
+	if err != nil {
+		log.Fatalf("ykush3d: failed to start device manager: %v", err)
+	}
+	defer mgr.Close()
+
+	httpServer := &http.Server{Addr: *httpAddr, Handler: srv.Handler()}
+	go func() {
+		log.Printf("ykush3d: REST API listening on %s", *httpAddr)
+		if err := httpServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			log.Fatalf("ykush3d: REST server failed: %v", err)
+		}
+	}()
+
+	grpcListener, err := net.Listen("tcp", *grpcAddr)
+	if err != nil {
+		log.Fatalf("ykush3d: failed to listen on %s: %v", *grpcAddr, err)
+	}
+	grpcServer := grpc.NewServer(ykushpb.ServerCodec())
+	ykushpb.RegisterYkushServiceServer(grpcServer, server.NewGRPCService(srv))
+	go func() {
+		log.Printf("ykush3d: gRPC service listening on %s", *grpcAddr)
+		if err := grpcServer.Serve(grpcListener); err != nil {
+			log.Fatalf("ykush3d: gRPC server failed: %v", err)
+		}
+	}()
+
+	<-ctx.Done()
+	log.Println("ykush3d: shutting down")
+	grpcServer.GracefulStop()
+	_ = httpServer.Shutdown(context.Background())
+}
@@ -0,0 +1,121 @@
+package ykush
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/sstallion/go-hid"
+)
+
+// readPollInterval is the delay between non-blocking reads while waiting for a response in
+// sendCommandCtx.
+const readPollInterval = 5 * time.Millisecond
+
+// hidDevice is the subset of *hid.Device's API conn depends on. It exists
+// so tests can exercise command framing, locking, and typed errors with a
+// fake, without real HID hardware; *hid.Device satisfies it as-is.
+type hidDevice interface {
+	Write(b []byte) (int, error)
+	Read(b []byte) (int, error)
+	SetNonblock(bool) error
+	GetSerialNbr() (string, error)
+	Close() error
+}
+
+var _ hidDevice = (*hid.Device)(nil)
+
+// conn serializes all HID I/O for a single device so concurrent callers
+// (e.g. two goroutines calling PortUp and GetPortState at once) can't
+// interleave HID reports and corrupt each other's responses. It's embedded
+// in every per-model Device implementation.
+type conn struct {
+	mu     sync.Mutex
+	device hidDevice
+}
+
+// Close closes the connection to the device and releases resources.
+func (c *conn) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.device == nil {
+		return nil
+	}
+	err := c.device.Close()
+	c.device = nil
+	return err
+}
+
+// GetSerial returns the serial number of the connected device.
+func (c *conn) GetSerial() (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.device == nil {
+		return "", ErrNotConnected
+	}
+	return c.device.GetSerialNbr()
+}
+
+// sendCommand sends a command to the device and returns the response. It
+// blocks indefinitely for the response; use sendCommandCtx to bound the
+// wait.
+func (c *conn) sendCommand(cmd, ctrl byte) ([]byte, error) {
+	return c.sendCommandCtx(context.Background(), cmd, ctrl)
+}
+
+// sendCommandCtx is like sendCommand but honors ctx's deadline and
+// cancellation. When ctx can be done, the read is driven through
+// hid.Device.SetNonblock and polled so it can be abandoned as soon as ctx
+// is done.
+func (c *conn) sendCommandCtx(ctx context.Context, cmd, ctrl byte) ([]byte, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.device == nil {
+		return nil, ErrNotConnected
+	}
+
+	cmdBuf := make([]byte, ReportSize)
+	cmdBuf[0] = cmd
+	cmdBuf[1] = ctrl
+
+	if _, err := c.device.Write(cmdBuf); err != nil {
+		return nil, fmt.Errorf("%w: failed to send command: %v", ErrDeviceRemoved, err)
+	}
+
+	if ctx.Done() == nil {
+		respBuf := make([]byte, ReportSize)
+		if _, err := c.device.Read(respBuf); err != nil {
+			return nil, fmt.Errorf("%w: failed to read response: %v", ErrDeviceRemoved, err)
+		}
+		return respBuf, nil
+	}
+
+	if err := c.device.SetNonblock(true); err != nil {
+		return nil, fmt.Errorf("ykush: failed to enable non-blocking reads: %w", err)
+	}
+	defer c.device.SetNonblock(false)
+
+	respBuf := make([]byte, ReportSize)
+	ticker := time.NewTicker(readPollInterval)
+	defer ticker.Stop()
+
+	for {
+		n, err := c.device.Read(respBuf)
+		if err != nil {
+			return nil, fmt.Errorf("%w: failed to read response: %v", ErrDeviceRemoved, err)
+		}
+		if n > 0 {
+			return respBuf, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("ykush: stopped waiting for response: %w", ctx.Err())
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,160 @@
+package ykush
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeHIDDevice is a minimal in-memory hidDevice used to test conn without
+// real HID hardware.
+type fakeHIDDevice struct {
+	mu sync.Mutex
+
+	written     [][]byte
+	response    []byte
+	readDelay   time.Duration
+	nonblock    bool
+	writeErr    error
+	readErr     error
+	activeCalls int
+	maxActive   int
+}
+
+// Write and Read together track activeCalls across a whole Write-then-Read
+// transaction (the shape every sendCommand call takes), so a test can
+// assert no two transactions ever overlap.
+
+func (f *fakeHIDDevice) Write(b []byte) (int, error) {
+	f.mu.Lock()
+	f.activeCalls++
+	if f.activeCalls > f.maxActive {
+		f.maxActive = f.activeCalls
+	}
+	f.mu.Unlock()
+
+	if f.writeErr != nil {
+		f.mu.Lock()
+		f.activeCalls--
+		f.mu.Unlock()
+		return 0, f.writeErr
+	}
+	cp := append([]byte(nil), b...)
+	f.mu.Lock()
+	f.written = append(f.written, cp)
+	f.mu.Unlock()
+	return len(b), nil
+}
+
+func (f *fakeHIDDevice) Read(b []byte) (int, error) {
+	defer func() {
+		f.mu.Lock()
+		f.activeCalls--
+		f.mu.Unlock()
+	}()
+
+	if f.readErr != nil {
+		return 0, f.readErr
+	}
+	if f.readDelay > 0 {
+		if f.nonblock {
+			// Simulate hidapi's non-blocking behavior for a response
+			// that never arrives: return immediately with no data.
+			return 0, nil
+		}
+		time.Sleep(f.readDelay)
+	}
+	n := copy(b, f.response)
+	return n, nil
+}
+
+func (f *fakeHIDDevice) SetNonblock(on bool) error {
+	f.nonblock = on
+	return nil
+}
+
+func (f *fakeHIDDevice) GetSerialNbr() (string, error) { return "fake-serial", nil }
+func (f *fakeHIDDevice) Close() error                  { return nil }
+
+func newFakeConn(resp []byte) (*conn, *fakeHIDDevice) {
+	f := &fakeHIDDevice{response: resp}
+	return &conn{device: f}, f
+}
+
+func TestConnSendCommandFramesRequest(t *testing.T) {
+	c, f := newFakeConn([]byte{0x01, 0x11})
+
+	resp, err := c.sendCommand(0x11, 0x11)
+	if err != nil {
+		t.Fatalf("sendCommand: %v", err)
+	}
+	if resp[0] != 0x01 || resp[1] != 0x11 {
+		t.Fatalf("unexpected response: %v", resp)
+	}
+
+	if len(f.written) != 1 {
+		t.Fatalf("expected 1 write, got %d", len(f.written))
+	}
+	got := f.written[0]
+	if len(got) != ReportSize || got[0] != 0x11 || got[1] != 0x11 {
+		t.Fatalf("unexpected command frame: %v", got)
+	}
+}
+
+func TestConnNotConnected(t *testing.T) {
+	c := &conn{}
+
+	if _, err := c.sendCommand(0x11, 0x11); !errors.Is(err, ErrNotConnected) {
+		t.Fatalf("expected ErrNotConnected, got %v", err)
+	}
+	if _, err := c.GetSerial(); !errors.Is(err, ErrNotConnected) {
+		t.Fatalf("expected ErrNotConnected, got %v", err)
+	}
+}
+
+func TestConnDeviceRemoved(t *testing.T) {
+	c, f := newFakeConn(nil)
+	f.writeErr = errors.New("write: no such device")
+
+	if _, err := c.sendCommand(0x11, 0x11); !errors.Is(err, ErrDeviceRemoved) {
+		t.Fatalf("expected ErrDeviceRemoved, got %v", err)
+	}
+}
+
+func TestConnSendCommandCtxTimesOut(t *testing.T) {
+	c, f := newFakeConn(nil)
+	f.readDelay = time.Hour // never actually resolves within the test
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	_, err := c.sendCommandCtx(ctx, 0x11, 0x11)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected context.DeadlineExceeded, got %v", err)
+	}
+}
+
+func TestConnSendCommandSerializesConcurrentCallers(t *testing.T) {
+	c, f := newFakeConn([]byte{0x01, 0x11})
+	f.readDelay = 10 * time.Millisecond
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := c.sendCommand(0x11, 0x11); err != nil {
+				t.Errorf("sendCommand: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.maxActive != 1 {
+		t.Fatalf("expected at most 1 concurrent HID transaction, saw %d", f.maxActive)
+	}
+}
@@ -0,0 +1,34 @@
+package ykush
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	// ErrNotConnected is returned when an operation is attempted on a
+	// Device that has already been closed.
+	ErrNotConnected = errors.New("ykush: device not connected")
+	// ErrInvalidPort is returned when a Port value isn't valid for the
+	// operation being performed, e.g. AllPorts passed to GetPortState.
+	ErrInvalidPort = errors.New("ykush: invalid port")
+	// ErrDeviceRemoved is returned when a command fails because the
+	// underlying USB device was unplugged mid-transaction. Callers can
+	// match it with errors.Is to implement retry-on-disconnect logic.
+	ErrDeviceRemoved = errors.New("ykush: device removed")
+	// ErrUnsupportedModel is returned by every operation on a Device whose
+	// model's command set isn't implemented yet. See the doc comment on
+	// unsupportedDevice for why.
+	ErrUnsupportedModel = errors.New("ykush: model not supported")
+)
+
+// ErrUnexpectedResponse is returned when a device replies to a command
+// with a status or response byte that doesn't match what was expected.
+type ErrUnexpectedResponse struct {
+	Status   byte
+	Response byte
+}
+
+func (e *ErrUnexpectedResponse) Error() string {
+	return fmt.Sprintf("ykush: unexpected response: status=0x%02x, response=0x%02x", e.Status, e.Response)
+}
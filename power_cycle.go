@@ -0,0 +1,82 @@
+package ykush
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// PowerCycleOptions configures PowerCycle and CycleAll.
+type PowerCycleOptions struct {
+	// OffDuration is how long the port is held off before being turned
+	// back on.
+	OffDuration time.Duration
+	// SettleDuration is an additional delay after the port is turned back
+	// on, before WaitFor (if any) is invoked.
+	SettleDuration time.Duration
+	// Context, if non-nil, bounds the whole operation: OffDuration,
+	// SettleDuration, and WaitFor are all canceled as soon as it's done.
+	// Defaults to context.Background().
+	Context context.Context
+	// WaitFor, if set, is called after SettleDuration elapses and should
+	// block until the downstream device has re-enumerated, e.g. by
+	// polling hid.Enumerate for its VID/PID or watching /dev/serial/by-id.
+	WaitFor func(ctx context.Context) error
+}
+
+// PowerCycle turns port off, waits OffDuration, turns it back on, waits
+// SettleDuration, and then runs opts.WaitFor if one was supplied. Any step
+// that outlives opts.Context is aborted and its error returned.
+func PowerCycle(dev Device, port Port, opts PowerCycleOptions) error {
+	ctx := opts.Context
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	if err := dev.PortDownCtx(ctx, port); err != nil {
+		return fmt.Errorf("failed to power off %v: %w", port, err)
+	}
+
+	if err := sleepCtx(ctx, opts.OffDuration); err != nil {
+		return err
+	}
+
+	if err := dev.PortUpCtx(ctx, port); err != nil {
+		return fmt.Errorf("failed to power on %v: %w", port, err)
+	}
+
+	if err := sleepCtx(ctx, opts.SettleDuration); err != nil {
+		return err
+	}
+
+	if opts.WaitFor != nil {
+		if err := opts.WaitFor(ctx); err != nil {
+			return fmt.Errorf("waiting for %v to become ready: %w", port, err)
+		}
+	}
+
+	return nil
+}
+
+// CycleAll powers off all ports, waits, and powers them back on, as
+// PowerCycle does for a single port.
+func CycleAll(dev Device, opts PowerCycleOptions) error {
+	return PowerCycle(dev, AllPorts, opts)
+}
+
+// sleepCtx blocks for d or until ctx is done, whichever comes first.
+func sleepCtx(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,137 @@
+package ykush
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// fakePowerDevice records PortUp/PortDown calls for exercising PowerCycle
+// and CycleAll without real hardware.
+type fakePowerDevice struct {
+	calls []string
+
+	downErr error
+	upErr   error
+}
+
+func (d *fakePowerDevice) PortUp(port Port) error {
+	d.calls = append(d.calls, "up:"+port.String())
+	return d.upErr
+}
+
+func (d *fakePowerDevice) PortDown(port Port) error {
+	d.calls = append(d.calls, "down:"+port.String())
+	return d.downErr
+}
+
+func (d *fakePowerDevice) PortUpCtx(ctx context.Context, port Port) error   { return d.PortUp(port) }
+func (d *fakePowerDevice) PortDownCtx(ctx context.Context, port Port) error { return d.PortDown(port) }
+
+func (d *fakePowerDevice) GetPortState(port Port) (PortState, error) { return PortOff, nil }
+func (d *fakePowerDevice) GetPortStateCtx(ctx context.Context, port Port) (PortState, error) {
+	return PortOff, nil
+}
+
+func (d *fakePowerDevice) Ports() []Port { return []Port{Port1, Port2, Port3} }
+func (d *fakePowerDevice) Model() string { return "fake" }
+func (d *fakePowerDevice) Close() error  { return nil }
+
+var _ Device = (*fakePowerDevice)(nil)
+
+func TestPowerCycleSequencesDownUpWaitFor(t *testing.T) {
+	dev := &fakePowerDevice{}
+	var waitForCalled bool
+
+	err := PowerCycle(dev, Port1, PowerCycleOptions{
+		OffDuration:    time.Millisecond,
+		SettleDuration: time.Millisecond,
+		WaitFor: func(ctx context.Context) error {
+			waitForCalled = true
+			return nil
+		},
+	})
+	if err != nil {
+		t.Fatalf("PowerCycle: %v", err)
+	}
+
+	want := []string{"down:" + Port1.String(), "up:" + Port1.String()}
+	if len(dev.calls) != 2 || dev.calls[0] != want[0] || dev.calls[1] != want[1] {
+		t.Fatalf("PowerCycle calls = %v, want %v", dev.calls, want)
+	}
+	if !waitForCalled {
+		t.Fatalf("expected WaitFor to be called")
+	}
+}
+
+func TestPowerCyclePropagatesPortDownError(t *testing.T) {
+	dev := &fakePowerDevice{downErr: errors.New("boom")}
+
+	err := PowerCycle(dev, Port1, PowerCycleOptions{})
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+	if len(dev.calls) != 1 {
+		t.Fatalf("expected PortUp to be skipped after PortDown failed, got calls = %v", dev.calls)
+	}
+}
+
+func TestPowerCyclePropagatesPortUpError(t *testing.T) {
+	dev := &fakePowerDevice{upErr: errors.New("boom")}
+
+	err := PowerCycle(dev, Port1, PowerCycleOptions{})
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+}
+
+func TestPowerCycleAbortsOnContextCancelDuringOff(t *testing.T) {
+	dev := &fakePowerDevice{}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err := PowerCycle(dev, Port1, PowerCycleOptions{
+		Context:     ctx,
+		OffDuration: time.Hour,
+		WaitFor: func(ctx context.Context) error {
+			t.Fatal("WaitFor should not be called when the off-wait was canceled")
+			return nil
+		},
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+	if len(dev.calls) != 1 {
+		t.Fatalf("expected only PortDown to have run, got calls = %v", dev.calls)
+	}
+}
+
+func TestPowerCycleAbortsOnContextCancelDuringWaitFor(t *testing.T) {
+	dev := &fakePowerDevice{}
+	ctx, cancel := context.WithCancel(context.Background())
+
+	err := PowerCycle(dev, Port1, PowerCycleOptions{
+		Context: ctx,
+		WaitFor: func(ctx context.Context) error {
+			cancel()
+			return ctx.Err()
+		},
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+}
+
+func TestCycleAllTargetsAllPorts(t *testing.T) {
+	dev := &fakePowerDevice{}
+
+	if err := CycleAll(dev, PowerCycleOptions{}); err != nil {
+		t.Fatalf("CycleAll: %v", err)
+	}
+
+	want := []string{"down:" + AllPorts.String(), "up:" + AllPorts.String()}
+	if len(dev.calls) != 2 || dev.calls[0] != want[0] || dev.calls[1] != want[1] {
+		t.Fatalf("CycleAll calls = %v, want %v", dev.calls, want)
+	}
+}
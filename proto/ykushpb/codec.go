@@ -0,0 +1,54 @@
+package ykushpb
+
+import (
+	"encoding/json"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/encoding"
+)
+
+// CodecName is the gRPC content-subtype jsonCodec is registered under. It's
+// deliberately not "proto" (grpc-go's default): encoding.RegisterCodec has
+// one process-wide registry shared by every gRPC client and server in the
+// binary, so registering under the default name would silently swap every
+// other proto.Message in the process over to JSON-on-the-wire the instant
+// this package is imported. Registering under a distinct name instead
+// means jsonCodec only applies to calls that opt in with CallOption/
+// ServerCodec below.
+const CodecName = "ykush-json"
+
+// jsonCodec implements encoding.Codec by marshaling through the `json:`
+// struct tags already present on the message types in ykush.pb.go, since
+// they don't implement proto.Message and can't go through grpc-go's real
+// protobuf codec.
+type jsonCodec struct{}
+
+func (jsonCodec) Marshal(v interface{}) ([]byte, error) {
+	return json.Marshal(v)
+}
+
+func (jsonCodec) Unmarshal(data []byte, v interface{}) error {
+	return json.Unmarshal(data, v)
+}
+
+func (jsonCodec) Name() string {
+	return CodecName
+}
+
+func init() {
+	encoding.RegisterCodec(jsonCodec{})
+}
+
+// ServerCodec returns a grpc.ServerOption that makes a grpc.Server encode
+// and decode YkushService calls with jsonCodec, e.g.
+// grpc.NewServer(ykushpb.ServerCodec()).
+func ServerCodec() grpc.ServerOption {
+	return grpc.ForceServerCodec(jsonCodec{})
+}
+
+// CallOption returns a grpc.CallOption that makes a YkushServiceClient call
+// use jsonCodec instead of grpc-go's default protobuf codec, e.g.
+// client.ListDevices(ctx, req, ykushpb.CallOption()).
+func CallOption() grpc.CallOption {
+	return grpc.CallContentSubtype(CodecName)
+}
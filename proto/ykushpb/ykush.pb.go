@@ -0,0 +1,117 @@
+// Package ykushpb defines the message and service types for the YkushService
+// gRPC API described by proto/ykush.proto.
+//
+// These types are hand-written, not protoc-gen-go output: they don't
+// implement proto.Message (no Reset/String/ProtoReflect), so they can't go
+// through grpc-go's default protobuf codec. Instead codec.go registers a
+// JSON-based grpc/encoding.Codec (via their `json:` tags, already present
+// for reuse by the REST layer) under the distinct "ykush-json"
+// content-subtype name -- see CodecName's doc comment for why it isn't
+// registered under grpc-go's default "proto" name instead. Servers and
+// clients need to opt in explicitly with ServerCodec/CallOption for calls
+// to actually marshal correctly over the wire. If proto/ykush.proto
+// changes, update these types and codec.go by hand to match; there is no
+// `go generate` step backing this package.
+package ykushpb
+
+type ListDevicesRequest struct{}
+
+type ListDevicesResponse struct {
+	Devices []*Device `protobuf:"bytes,1,rep,name=devices,proto3" json:"devices,omitempty"`
+}
+
+func (m *ListDevicesResponse) GetDevices() []*Device {
+	if m != nil {
+		return m.Devices
+	}
+	return nil
+}
+
+type Device struct {
+	Serial string `protobuf:"bytes,1,opt,name=serial,proto3" json:"serial,omitempty"`
+	Model  string `protobuf:"bytes,2,opt,name=model,proto3" json:"model,omitempty"`
+}
+
+func (m *Device) GetSerial() string {
+	if m != nil {
+		return m.Serial
+	}
+	return ""
+}
+
+func (m *Device) GetModel() string {
+	if m != nil {
+		return m.Model
+	}
+	return ""
+}
+
+type ListPortsRequest struct {
+	Serial string `protobuf:"bytes,1,opt,name=serial,proto3" json:"serial,omitempty"`
+}
+
+func (m *ListPortsRequest) GetSerial() string {
+	if m != nil {
+		return m.Serial
+	}
+	return ""
+}
+
+type ListPortsResponse struct {
+	Ports []*PortState `protobuf:"bytes,1,rep,name=ports,proto3" json:"ports,omitempty"`
+}
+
+func (m *ListPortsResponse) GetPorts() []*PortState {
+	if m != nil {
+		return m.Ports
+	}
+	return nil
+}
+
+type PortState struct {
+	Port int32 `protobuf:"varint,1,opt,name=port,proto3" json:"port,omitempty"`
+	On   bool  `protobuf:"varint,2,opt,name=on,proto3" json:"on,omitempty"`
+}
+
+func (m *PortState) GetPort() int32 {
+	if m != nil {
+		return m.Port
+	}
+	return 0
+}
+
+func (m *PortState) GetOn() bool {
+	if m != nil {
+		return m.On
+	}
+	return false
+}
+
+type SetPortStateRequest struct {
+	Serial string `protobuf:"bytes,1,opt,name=serial,proto3" json:"serial,omitempty"`
+	Port   int32  `protobuf:"varint,2,opt,name=port,proto3" json:"port,omitempty"`
+	On     bool   `protobuf:"varint,3,opt,name=on,proto3" json:"on,omitempty"`
+}
+
+func (m *SetPortStateRequest) GetSerial() string {
+	if m != nil {
+		return m.Serial
+	}
+	return ""
+}
+
+func (m *SetPortStateRequest) GetPort() int32 {
+	if m != nil {
+		return m.Port
+	}
+	return 0
+}
+
+func (m *SetPortStateRequest) GetOn() bool {
+	if m != nil {
+		return m.On
+	}
+	return false
+}
+
+type SetPortStateResponse struct{}
@@ -0,0 +1,140 @@
+// Hand-written client/server scaffolding for the YkushService described by
+// proto/ykush.proto, in the shape protoc-gen-go-grpc would emit. See the
+// package doc comment in ykush.pb.go for why this isn't real codegen output
+// and how wire encoding is actually handled.
+package ykushpb
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// YkushServiceClient is the client API for YkushService.
+type YkushServiceClient interface {
+	ListDevices(ctx context.Context, in *ListDevicesRequest, opts ...grpc.CallOption) (*ListDevicesResponse, error)
+	ListPorts(ctx context.Context, in *ListPortsRequest, opts ...grpc.CallOption) (*ListPortsResponse, error)
+	SetPortState(ctx context.Context, in *SetPortStateRequest, opts ...grpc.CallOption) (*SetPortStateResponse, error)
+}
+
+type ykushServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+// NewYkushServiceClient returns a client for YkushService backed by cc.
+func NewYkushServiceClient(cc grpc.ClientConnInterface) YkushServiceClient {
+	return &ykushServiceClient{cc}
+}
+
+func (c *ykushServiceClient) ListDevices(ctx context.Context, in *ListDevicesRequest, opts ...grpc.CallOption) (*ListDevicesResponse, error) {
+	out := new(ListDevicesResponse)
+	if err := c.cc.Invoke(ctx, "/ykush.YkushService/ListDevices", in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ykushServiceClient) ListPorts(ctx context.Context, in *ListPortsRequest, opts ...grpc.CallOption) (*ListPortsResponse, error) {
+	out := new(ListPortsResponse)
+	if err := c.cc.Invoke(ctx, "/ykush.YkushService/ListPorts", in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ykushServiceClient) SetPortState(ctx context.Context, in *SetPortStateRequest, opts ...grpc.CallOption) (*SetPortStateResponse, error) {
+	out := new(SetPortStateResponse)
+	if err := c.cc.Invoke(ctx, "/ykush.YkushService/SetPortState", in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// YkushServiceServer is the server API for YkushService.
+type YkushServiceServer interface {
+	ListDevices(context.Context, *ListDevicesRequest) (*ListDevicesResponse, error)
+	ListPorts(context.Context, *ListPortsRequest) (*ListPortsResponse, error)
+	SetPortState(context.Context, *SetPortStateRequest) (*SetPortStateResponse, error)
+}
+
+// UnimplementedYkushServiceServer can be embedded in an implementation to
+// satisfy YkushServiceServer with default "not implemented" behavior for
+// any method not overridden.
+type UnimplementedYkushServiceServer struct{}
+
+func (UnimplementedYkushServiceServer) ListDevices(context.Context, *ListDevicesRequest) (*ListDevicesResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListDevices not implemented")
+}
+
+func (UnimplementedYkushServiceServer) ListPorts(context.Context, *ListPortsRequest) (*ListPortsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListPorts not implemented")
+}
+
+func (UnimplementedYkushServiceServer) SetPortState(context.Context, *SetPortStateRequest) (*SetPortStateResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method SetPortState not implemented")
+}
+
+// RegisterYkushServiceServer registers srv with s.
+func RegisterYkushServiceServer(s grpc.ServiceRegistrar, srv YkushServiceServer) {
+	s.RegisterService(&YkushService_ServiceDesc, srv)
+}
+
+func _YkushService_ListDevices_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListDevicesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(YkushServiceServer).ListDevices(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/ykush.YkushService/ListDevices"}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(YkushServiceServer).ListDevices(ctx, req.(*ListDevicesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _YkushService_ListPorts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListPortsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(YkushServiceServer).ListPorts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/ykush.YkushService/ListPorts"}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(YkushServiceServer).ListPorts(ctx, req.(*ListPortsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _YkushService_SetPortState_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetPortStateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(YkushServiceServer).SetPortState(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/ykush.YkushService/SetPortState"}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(YkushServiceServer).SetPortState(ctx, req.(*SetPortStateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// YkushService_ServiceDesc is the grpc.ServiceDesc for YkushService.
+var YkushService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "ykush.YkushService",
+	HandlerType: (*YkushServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{MethodName: "ListDevices", Handler: _YkushService_ListDevices_Handler},
+		{MethodName: "ListPorts", Handler: _YkushService_ListPorts_Handler},
+		{MethodName: "SetPortState", Handler: _YkushService_SetPortState_Handler},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "proto/ykush.proto",
+}
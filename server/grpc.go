@@ -0,0 +1,74 @@
+package server
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/fcjr/ykush3"
+	"github.com/fcjr/ykush3/proto/ykushpb"
+)
+
+// GRPCService adapts Server to the ykushpb.YkushServiceServer interface so
+// the same device registry can be served over gRPC alongside REST.
+type GRPCService struct {
+	ykushpb.UnimplementedYkushServiceServer
+	srv *Server
+}
+
+// NewGRPCService wraps srv for use with ykushpb.RegisterYkushServiceServer.
+func NewGRPCService(srv *Server) *GRPCService {
+	return &GRPCService{srv: srv}
+}
+
+func (g *GRPCService) ListDevices(ctx context.Context, _ *ykushpb.ListDevicesRequest) (*ykushpb.ListDevicesResponse, error) {
+	g.srv.mu.RLock()
+	defer g.srv.mu.RUnlock()
+
+	resp := &ykushpb.ListDevicesResponse{}
+	for serial, md := range g.srv.devices {
+		resp.Devices = append(resp.Devices, &ykushpb.Device{Serial: serial, Model: md.dev.Model()})
+	}
+	return resp, nil
+}
+
+func (g *GRPCService) ListPorts(ctx context.Context, req *ykushpb.ListPortsRequest) (*ykushpb.ListPortsResponse, error) {
+	md, ok := g.srv.get(req.Serial)
+	if !ok {
+		return nil, fmt.Errorf("unknown device %q", req.Serial)
+	}
+
+	md.mu.Lock()
+	defer md.mu.Unlock()
+
+	resp := &ykushpb.ListPortsResponse{}
+	for _, port := range md.dev.Ports() {
+		state, err := md.dev.GetPortState(port)
+		if err != nil {
+			return nil, err
+		}
+		resp.Ports = append(resp.Ports, &ykushpb.PortState{Port: int32(port), On: bool(state)})
+	}
+	return resp, nil
+}
+
+func (g *GRPCService) SetPortState(ctx context.Context, req *ykushpb.SetPortStateRequest) (*ykushpb.SetPortStateResponse, error) {
+	md, ok := g.srv.get(req.Serial)
+	if !ok {
+		return nil, fmt.Errorf("unknown device %q", req.Serial)
+	}
+
+	md.mu.Lock()
+	defer md.mu.Unlock()
+
+	port := ykush.Port(req.Port)
+	var err error
+	if req.On {
+		err = md.dev.PortUp(port)
+	} else {
+		err = md.dev.PortDown(port)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &ykushpb.SetPortStateResponse{}, nil
+}
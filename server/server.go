@@ -0,0 +1,187 @@
+// Package server hosts one or more ykush.Device instances behind a REST
+// API and a gRPC service, so YKUSH devices attached to a lab host can be
+// shared by remote callers instead of requiring local HID access.
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/fcjr/ykush3"
+)
+
+// managedDevice serializes all HID I/O for a single device so concurrent
+// callers can't interleave commands on the wire.
+type managedDevice struct {
+	mu  sync.Mutex
+	dev ykush.Device
+}
+
+// Server exposes a set of ykush.Device instances, keyed by serial number,
+// over HTTP.
+type Server struct {
+	mu      sync.RWMutex
+	devices map[string]*managedDevice
+}
+
+// New creates an empty Server. Devices are added with Add as they're
+// discovered.
+func New() *Server {
+	return &Server{devices: make(map[string]*managedDevice)}
+}
+
+// Add registers dev under serial, making it reachable through the API.
+func (s *Server) Add(serial string, dev ykush.Device) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.devices[serial] = &managedDevice{dev: dev}
+}
+
+// Remove unregisters the device with the given serial number, if any.
+func (s *Server) Remove(serial string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.devices, serial)
+}
+
+func (s *Server) get(serial string) (*managedDevice, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	md, ok := s.devices[serial]
+	return md, ok
+}
+
+type deviceSummary struct {
+	Serial string `json:"serial"`
+	Model  string `json:"model"`
+}
+
+type portSummary struct {
+	Port  int  `json:"port"`
+	State bool `json:"state"`
+}
+
+type setStateRequest struct {
+	State string `json:"state"`
+}
+
+// Handler returns the REST API as an http.Handler, ready to be served
+// directly or mounted under a prefix.
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/devices", s.handleListDevices)
+	mux.HandleFunc("/devices/", s.handleDevice)
+	return mux
+}
+
+func (s *Server) handleListDevices(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	s.mu.RLock()
+	summaries := make([]deviceSummary, 0, len(s.devices))
+	for serial, md := range s.devices {
+		summaries = append(summaries, deviceSummary{Serial: serial, Model: md.dev.Model()})
+	}
+	s.mu.RUnlock()
+
+	writeJSON(w, http.StatusOK, summaries)
+}
+
+// handleDevice dispatches requests under /devices/{serial}/ports[/{n}].
+func (s *Server) handleDevice(w http.ResponseWriter, r *http.Request) {
+	path := strings.TrimPrefix(r.URL.Path, "/devices/")
+	parts := strings.Split(path, "/")
+	if len(parts) < 2 || parts[1] != "ports" {
+		http.NotFound(w, r)
+		return
+	}
+	serial := parts[0]
+
+	md, ok := s.get(serial)
+	if !ok {
+		http.Error(w, fmt.Sprintf("unknown device %q", serial), http.StatusNotFound)
+		return
+	}
+
+	switch {
+	case len(parts) == 2 && r.Method == http.MethodGet:
+		s.listPorts(w, md)
+	case len(parts) == 3 && r.Method == http.MethodPost:
+		s.setPort(w, r, md, parts[2])
+	default:
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+func (s *Server) listPorts(w http.ResponseWriter, md *managedDevice) {
+	md.mu.Lock()
+	defer md.mu.Unlock()
+
+	ports := md.dev.Ports()
+	summaries := make([]portSummary, 0, len(ports))
+	for _, port := range ports {
+		state, err := md.dev.GetPortState(port)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadGateway)
+			return
+		}
+		summaries = append(summaries, portSummary{Port: int(port), State: bool(state)})
+	}
+
+	writeJSON(w, http.StatusOK, summaries)
+}
+
+func (s *Server) setPort(w http.ResponseWriter, r *http.Request, md *managedDevice, portParam string) {
+	n, err := strconv.Atoi(portParam)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("invalid port %q", portParam), http.StatusBadRequest)
+		return
+	}
+
+	var body setStateRequest
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		http.Error(w, fmt.Sprintf("invalid request body: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	var state ykush.PortState
+	switch body.State {
+	case "on":
+		state = ykush.PortOn
+	case "off":
+		state = ykush.PortOff
+	default:
+		http.Error(w, fmt.Sprintf("invalid state %q, want \"on\" or \"off\"", body.State), http.StatusBadRequest)
+		return
+	}
+
+	md.mu.Lock()
+	defer md.mu.Unlock()
+
+	port := ykush.Port(n)
+	var opErr error
+	if state {
+		opErr = md.dev.PortUp(port)
+	} else {
+		opErr = md.dev.PortDown(port)
+	}
+	if opErr != nil {
+		http.Error(w, opErr.Error(), http.StatusBadGateway)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
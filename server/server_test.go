@@ -0,0 +1,152 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/fcjr/ykush3"
+)
+
+// fakeDevice is a minimal ykush.Device for exercising the REST handlers
+// without real hardware.
+type fakeDevice struct {
+	model string
+	ports []ykush.Port
+	state map[ykush.Port]ykush.PortState
+
+	setErr error
+}
+
+func newFakeDevice(model string, ports ...ykush.Port) *fakeDevice {
+	state := make(map[ykush.Port]ykush.PortState, len(ports))
+	for _, p := range ports {
+		state[p] = ykush.PortOff
+	}
+	return &fakeDevice{model: model, ports: ports, state: state}
+}
+
+func (d *fakeDevice) PortUp(port ykush.Port) error { return d.PortUpCtx(context.Background(), port) }
+func (d *fakeDevice) PortDown(port ykush.Port) error {
+	return d.PortDownCtx(context.Background(), port)
+}
+
+func (d *fakeDevice) PortUpCtx(_ context.Context, port ykush.Port) error {
+	if d.setErr != nil {
+		return d.setErr
+	}
+	d.state[port] = ykush.PortOn
+	return nil
+}
+
+func (d *fakeDevice) PortDownCtx(_ context.Context, port ykush.Port) error {
+	if d.setErr != nil {
+		return d.setErr
+	}
+	d.state[port] = ykush.PortOff
+	return nil
+}
+
+func (d *fakeDevice) GetPortState(port ykush.Port) (ykush.PortState, error) {
+	return d.GetPortStateCtx(context.Background(), port)
+}
+
+func (d *fakeDevice) GetPortStateCtx(_ context.Context, port ykush.Port) (ykush.PortState, error) {
+	return d.state[port], nil
+}
+
+func (d *fakeDevice) Ports() []ykush.Port { return d.ports }
+func (d *fakeDevice) Model() string       { return d.model }
+func (d *fakeDevice) Close() error        { return nil }
+
+var _ ykush.Device = (*fakeDevice)(nil)
+
+func TestHandleListDevices(t *testing.T) {
+	s := New()
+	s.Add("SERIAL1", newFakeDevice("YKUSH3", ykush.Port1, ykush.Port2, ykush.Port3))
+
+	rec := httptest.NewRecorder()
+	s.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/devices", nil))
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, body = %s", rec.Code, rec.Body)
+	}
+
+	var got []deviceSummary
+	if err := json.Unmarshal(rec.Body.Bytes(), &got); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	want := []deviceSummary{{Serial: "SERIAL1", Model: "YKUSH3"}}
+	if len(got) != 1 || got[0] != want[0] {
+		t.Fatalf("handleListDevices() = %#v, want %#v", got, want)
+	}
+}
+
+func TestHandleListPorts(t *testing.T) {
+	s := New()
+	dev := newFakeDevice("YKUSH3", ykush.Port1, ykush.Port2)
+	dev.state[ykush.Port1] = ykush.PortOn
+	s.Add("SERIAL1", dev)
+
+	rec := httptest.NewRecorder()
+	s.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/devices/SERIAL1/ports", nil))
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, body = %s", rec.Code, rec.Body)
+	}
+
+	var got []portSummary
+	if err := json.Unmarshal(rec.Body.Bytes(), &got); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	want := []portSummary{{Port: int(ykush.Port1), State: true}, {Port: int(ykush.Port2), State: false}}
+	if len(got) != len(want) || got[0] != want[0] || got[1] != want[1] {
+		t.Fatalf("handleListPorts() = %#v, want %#v", got, want)
+	}
+}
+
+func TestHandleSetPort(t *testing.T) {
+	s := New()
+	dev := newFakeDevice("YKUSH3", ykush.Port1)
+	s.Add("SERIAL1", dev)
+
+	body := strings.NewReader(`{"state":"on"}`)
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/devices/SERIAL1/ports/1", body)
+	s.Handler().ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusNoContent {
+		t.Fatalf("status = %d, body = %s", rec.Code, rec.Body)
+	}
+	if dev.state[ykush.Port1] != ykush.PortOn {
+		t.Fatalf("expected port 1 to be turned on")
+	}
+}
+
+func TestHandleSetPortUnknownDevice(t *testing.T) {
+	s := New()
+
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/devices/NOPE/ports/1", strings.NewReader(`{"state":"on"}`))
+	s.Handler().ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusNotFound {
+		t.Fatalf("status = %d, want %d", rec.Code, http.StatusNotFound)
+	}
+}
+
+func TestHandleSetPortInvalidState(t *testing.T) {
+	s := New()
+	s.Add("SERIAL1", newFakeDevice("YKUSH3", ykush.Port1))
+
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/devices/SERIAL1/ports/1", strings.NewReader(`{"state":"sideways"}`))
+	s.Handler().ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("status = %d, want %d", rec.Code, http.StatusBadRequest)
+	}
+}
@@ -0,0 +1,70 @@
+package ykush
+
+import (
+	"context"
+	"fmt"
+)
+
+// unsupportedDevice implements Device for every model in the YKUSH family
+// except YKUSH3. An earlier version of this package shipped guessed
+// per-model opcode tables for YKUSH, YKUSHXS, and the original YKUSH beta
+// board, copied byte-for-byte from YKUSH3 with no way to confirm them
+// against real hardware or Yepkit's reference firmware -- that's worse
+// than refusing to talk to these models, since a wrong opcode silently
+// sent to the wrong port is indistinguishable from a successful command.
+// Until someone can verify each model's actual command set (and, for
+// YKUSHXS, its actual port count -- it may not be 3) against real hardware
+// or vendor documentation, every operation on these models fails with
+// ErrUnsupportedModel instead of guessing.
+//
+// ListDevices and NewWithSerial still recognize and report these models by
+// product ID; only sending them commands is refused.
+type unsupportedDevice struct {
+	conn
+	model Model
+}
+
+// Model returns the human-readable name of the device model.
+func (d *unsupportedDevice) Model() string {
+	return d.model.String()
+}
+
+// Ports returns nil: the real port count for this model hasn't been
+// confirmed, so no set of ports can be offered as fact.
+func (d *unsupportedDevice) Ports() []Port {
+	return nil
+}
+
+// PortUp always returns ErrUnsupportedModel.
+func (d *unsupportedDevice) PortUp(port Port) error {
+	return d.PortUpCtx(context.Background(), port)
+}
+
+// PortUpCtx always returns ErrUnsupportedModel.
+func (d *unsupportedDevice) PortUpCtx(ctx context.Context, port Port) error {
+	return d.unsupportedErr()
+}
+
+// PortDown always returns ErrUnsupportedModel.
+func (d *unsupportedDevice) PortDown(port Port) error {
+	return d.PortDownCtx(context.Background(), port)
+}
+
+// PortDownCtx always returns ErrUnsupportedModel.
+func (d *unsupportedDevice) PortDownCtx(ctx context.Context, port Port) error {
+	return d.unsupportedErr()
+}
+
+// GetPortState always returns ErrUnsupportedModel.
+func (d *unsupportedDevice) GetPortState(port Port) (PortState, error) {
+	return d.GetPortStateCtx(context.Background(), port)
+}
+
+// GetPortStateCtx always returns ErrUnsupportedModel.
+func (d *unsupportedDevice) GetPortStateCtx(ctx context.Context, port Port) (PortState, error) {
+	return PortOff, d.unsupportedErr()
+}
+
+func (d *unsupportedDevice) unsupportedErr() error {
+	return fmt.Errorf("%w: %s", ErrUnsupportedModel, d.model)
+}
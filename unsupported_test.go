@@ -0,0 +1,52 @@
+package ykush
+
+import (
+	"errors"
+	"testing"
+)
+
+func newTestUnsupportedDevice(model Model) (*unsupportedDevice, *fakeHIDDevice) {
+	f := &fakeHIDDevice{}
+	return &unsupportedDevice{conn: conn{device: f}, model: model}, f
+}
+
+func TestUnsupportedDeviceRejectsPortOperations(t *testing.T) {
+	for _, model := range []Model{ModelYKUSHBeta, ModelYKUSH, ModelYKUSHXS} {
+		d, f := newTestUnsupportedDevice(model)
+
+		if err := d.PortUp(Port1); !errors.Is(err, ErrUnsupportedModel) {
+			t.Errorf("%v: PortUp: expected ErrUnsupportedModel, got %v", model, err)
+		}
+		if err := d.PortDown(Port1); !errors.Is(err, ErrUnsupportedModel) {
+			t.Errorf("%v: PortDown: expected ErrUnsupportedModel, got %v", model, err)
+		}
+		if _, err := d.GetPortState(Port1); !errors.Is(err, ErrUnsupportedModel) {
+			t.Errorf("%v: GetPortState: expected ErrUnsupportedModel, got %v", model, err)
+		}
+		if len(f.written) != 0 {
+			t.Errorf("%v: expected no bytes written to the device, got %v", model, f.written)
+		}
+		if d.Model() != model.String() {
+			t.Errorf("Model() = %q, want %q", d.Model(), model.String())
+		}
+		if d.Ports() != nil {
+			t.Errorf("Ports() = %v, want nil", d.Ports())
+		}
+	}
+}
+
+func TestNewDeviceDispatchesUnsupportedModels(t *testing.T) {
+	if _, ok := newDevice(ModelYKUSH3, nil).(*YKUSH3); !ok {
+		t.Fatalf("newDevice(ModelYKUSH3, ...) did not return a *YKUSH3")
+	}
+
+	for _, model := range []Model{ModelYKUSHBeta, ModelYKUSH, ModelYKUSHXS} {
+		dev, ok := newDevice(model, nil).(*unsupportedDevice)
+		if !ok {
+			t.Fatalf("newDevice(%v, ...) did not return an *unsupportedDevice", model)
+		}
+		if dev.model != model {
+			t.Fatalf("newDevice(%v, ...).model = %v", model, dev.model)
+		}
+	}
+}
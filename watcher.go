@@ -0,0 +1,252 @@
+package ykush
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/sstallion/go-hid"
+)
+
+// EventType identifies the kind of change a Watcher reported.
+type EventType int
+
+const (
+	// Attached indicates a device newly appeared on the bus.
+	Attached EventType = iota
+	// Detached indicates a previously seen device disappeared from the bus.
+	Detached
+)
+
+// String returns a human-readable representation of the event type.
+func (t EventType) String() string {
+	switch t {
+	case Attached:
+		return "attached"
+	case Detached:
+		return "detached"
+	default:
+		return fmt.Sprintf("unknown event (%d)", int(t))
+	}
+}
+
+// Event describes a YKUSH device attach or detach, keyed by serial number.
+type Event struct {
+	Type   EventType
+	Serial string
+	Info   DeviceInfo
+}
+
+// pollInterval is how often the fallback watcher re-enumerates devices when
+// the libusb-driven watch isn't available.
+const pollInterval = 500 * time.Millisecond
+
+// NewWatcher returns a channel that emits Attached/Detached events for
+// YKUSH devices of any supported model as they appear or disappear on the
+// bus. On Linux builds with cgo whose libusb has hotplug support, it
+// re-enumerates each time libusb's event wait returns (see
+// startHotplugWatch); no libusb hotplug callback is registered. Otherwise
+// it falls back to polling hid.Enumerate. The channel is closed when ctx is
+// canceled.
+func NewWatcher(ctx context.Context) (<-chan Event, error) {
+	if err := hid.Init(); err != nil {
+		return nil, fmt.Errorf("failed to initialize HID library: %w", err)
+	}
+
+	events := make(chan Event)
+	if !startHotplugWatch(ctx, events) {
+		go pollWatch(ctx, events)
+	}
+	return events, nil
+}
+
+// pollWatch implements the portable fallback: it periodically re-enumerates
+// devices and diffs the result against the previous snapshot.
+func pollWatch(ctx context.Context, events chan<- Event) {
+	defer close(events)
+
+	seen := make(map[string]DeviceInfo)
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	poll := func() {
+		current, err := ListDevices()
+		if err != nil {
+			return
+		}
+		var evs []Event
+		evs, seen = diffDeviceSets(seen, current)
+		for _, ev := range evs {
+			send(ctx, events, ev)
+		}
+	}
+
+	poll()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			poll()
+		}
+	}
+}
+
+// byserial indexes a device list by serial number, dropping entries with no
+// serial number since they can't be tracked across enumerations.
+func byserial(devices []DeviceInfo) map[string]DeviceInfo {
+	m := make(map[string]DeviceInfo, len(devices))
+	for _, info := range devices {
+		if info.SerialNbr == "" {
+			continue
+		}
+		m[info.SerialNbr] = info
+	}
+	return m
+}
+
+// diffDeviceSets compares a previous snapshot (seen) against a freshly
+// enumerated device list and returns the Attached/Detached events between
+// them, along with the new snapshot to pass as seen on the next call. It's
+// pure and allocation-only, so both pollWatch and the libusb hotplug loop
+// share it instead of keeping their own copies of the same diff logic.
+func diffDeviceSets(seen map[string]DeviceInfo, current []DeviceInfo) ([]Event, map[string]DeviceInfo) {
+	next := byserial(current)
+
+	var events []Event
+	for serial, info := range next {
+		if _, ok := seen[serial]; !ok {
+			events = append(events, Event{Type: Attached, Serial: serial, Info: info})
+		}
+	}
+	for serial, info := range seen {
+		if _, ok := next[serial]; !ok {
+			events = append(events, Event{Type: Detached, Serial: serial, Info: info})
+		}
+	}
+	return events, next
+}
+
+// send delivers an event, respecting context cancellation so a slow or
+// absent receiver can't block shutdown.
+func send(ctx context.Context, events chan<- Event, ev Event) {
+	select {
+	case events <- ev:
+	case <-ctx.Done():
+	}
+}
+
+// Manager maintains a live set of opened YKUSH devices, keyed by serial
+// number, updating it as devices are attached and detached.
+type Manager struct {
+	mu      sync.RWMutex
+	devices map[string]Device
+
+	onAttach func(serial string, dev Device)
+	onDetach func(serial string, dev Device)
+
+	// openFunc opens the device for a serial number reported as attached.
+	// It defaults to NewWithSerial; tests substitute a fake so the attach
+	// path can run without real HID hardware.
+	openFunc func(serial string) (Device, error)
+}
+
+// ManagerOption configures optional behavior when constructing a Manager.
+type ManagerOption func(*Manager)
+
+// WithOnAttach registers a callback invoked as devices are added to the
+// Manager. It lets callers (e.g. ykush3d) mirror the Manager's contents into
+// another registry without polling it.
+func WithOnAttach(fn func(serial string, dev Device)) ManagerOption {
+	return func(m *Manager) { m.onAttach = fn }
+}
+
+// WithOnDetach registers a callback invoked as devices are removed from the
+// Manager.
+func WithOnDetach(fn func(serial string, dev Device)) ManagerOption {
+	return func(m *Manager) { m.onDetach = fn }
+}
+
+// NewManager creates a Manager and starts a Watcher to keep it in sync with
+// the bus. Any ManagerOptions are applied before the Watcher's first event
+// can be processed, so callbacks passed via WithOnAttach/WithOnDetach never
+// race the Manager's internal goroutine. The Manager stops watching when ctx
+// is canceled; callers should call Close to release any devices it still
+// holds open at that point.
+func NewManager(ctx context.Context, opts ...ManagerOption) (*Manager, error) {
+	events, err := NewWatcher(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	m := &Manager{devices: make(map[string]Device), openFunc: NewWithSerial}
+	for _, opt := range opts {
+		opt(m)
+	}
+
+	go m.run(events)
+	return m, nil
+}
+
+func (m *Manager) run(events <-chan Event) {
+	for ev := range events {
+		switch ev.Type {
+		case Attached:
+			dev, err := m.openFunc(ev.Serial)
+			if err != nil {
+				continue
+			}
+			m.mu.Lock()
+			m.devices[ev.Serial] = dev
+			m.mu.Unlock()
+			if m.onAttach != nil {
+				m.onAttach(ev.Serial, dev)
+			}
+		case Detached:
+			m.mu.Lock()
+			dev, ok := m.devices[ev.Serial]
+			delete(m.devices, ev.Serial)
+			m.mu.Unlock()
+			if ok {
+				if m.onDetach != nil {
+					m.onDetach(ev.Serial, dev)
+				}
+				dev.Close()
+			}
+		}
+	}
+}
+
+// Get returns the currently open device with the given serial number, if
+// any.
+func (m *Manager) Get(serial string) (Device, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	dev, ok := m.devices[serial]
+	return dev, ok
+}
+
+// ForEach calls fn for every device the Manager currently holds open.
+func (m *Manager) ForEach(fn func(serial string, dev Device)) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	for serial, dev := range m.devices {
+		fn(serial, dev)
+	}
+}
+
+// Close closes every device the Manager currently holds open.
+func (m *Manager) Close() error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var firstErr error
+	for serial, dev := range m.devices {
+		if err := dev.Close(); err != nil && firstErr == nil {
+			firstErr = err
+		}
+		delete(m.devices, serial)
+	}
+	return firstErr
+}
@@ -0,0 +1,72 @@
+//go:build linux && cgo
+
+package ykush
+
+// #cgo LDFLAGS: -lusb-1.0
+// #include <libusb-1.0/libusb.h>
+import "C"
+
+import (
+	"context"
+)
+
+// startHotplugWatch attempts to drive events off libusb, which on Linux can
+// wake up for bus changes instead of polling on a timer. It reports whether
+// libusb initialized successfully; callers fall back to pollWatch when it
+// returns false.
+//
+// This does not register a libusb hotplug callback: libusb only supports
+// one hotplug callback mechanism (a C function pointer invoked directly
+// from its event-handling thread), and there's no portable way to get from
+// that C callback to a Go channel send without cgo call machinery running
+// inside libusb's own thread. Instead, libusb_handle_events_timeout is used
+// purely as a blocking wait for "something happened on the bus" (it returns
+// promptly on arrival/removal and at worst after the timeout), and the
+// actual diffing re-enumerates with hid.Enumerate via the same
+// diffDeviceSets logic pollWatch uses. This is slightly less immediate than
+// a true callback but avoids crashing the process and avoids keeping two
+// divergent diff implementations in sync.
+func startHotplugWatch(ctx context.Context, events chan<- Event) bool {
+	if C.libusb_has_capability(C.LIBUSB_CAP_HAS_HOTPLUG) == 0 {
+		return false
+	}
+
+	var usbCtx *C.libusb_context
+	if C.libusb_init(&usbCtx) != 0 {
+		return false
+	}
+
+	go runHotplugLoop(ctx, usbCtx, events)
+	return true
+}
+
+func runHotplugLoop(ctx context.Context, usbCtx *C.libusb_context, events chan<- Event) {
+	defer close(events)
+	defer C.libusb_exit(usbCtx)
+
+	seen := make(map[string]DeviceInfo)
+	resync := func() {
+		current, err := ListDevices()
+		if err != nil {
+			return
+		}
+		var evs []Event
+		evs, seen = diffDeviceSets(seen, current)
+		for _, ev := range evs {
+			send(ctx, events, ev)
+		}
+	}
+
+	resync()
+	var tv C.struct_timeval
+	tv.tv_sec = 1
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		default:
+			C.libusb_handle_events_timeout(usbCtx, &tv)
+			resync()
+		}
+	}
+}
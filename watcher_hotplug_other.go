@@ -0,0 +1,11 @@
+//go:build !(linux && cgo)
+
+package ykush
+
+import "context"
+
+// startHotplugWatch reports that no native hotplug mechanism is available
+// on this platform/build, so NewWatcher falls back to pollWatch.
+func startHotplugWatch(ctx context.Context, events chan<- Event) bool {
+	return false
+}
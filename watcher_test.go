@@ -0,0 +1,156 @@
+package ykush
+
+import (
+	"errors"
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/sstallion/go-hid"
+)
+
+func devInfo(serial string) DeviceInfo {
+	return DeviceInfo{DeviceInfo: hid.DeviceInfo{SerialNbr: serial}, Model: ModelYKUSH3}
+}
+
+func sortEvents(evs []Event) {
+	sort.Slice(evs, func(i, j int) bool {
+		if evs[i].Serial != evs[j].Serial {
+			return evs[i].Serial < evs[j].Serial
+		}
+		return evs[i].Type < evs[j].Type
+	})
+}
+
+func TestDiffDeviceSetsAttachAndDetach(t *testing.T) {
+	seen := map[string]DeviceInfo{
+		"AAA": devInfo("AAA"),
+		"BBB": devInfo("BBB"),
+	}
+	current := []DeviceInfo{devInfo("BBB"), devInfo("CCC")}
+
+	events, next := diffDeviceSets(seen, current)
+	sortEvents(events)
+
+	want := []Event{
+		{Type: Detached, Serial: "AAA", Info: devInfo("AAA")},
+		{Type: Attached, Serial: "CCC", Info: devInfo("CCC")},
+	}
+	if !reflect.DeepEqual(events, want) {
+		t.Fatalf("diffDeviceSets() events = %#v, want %#v", events, want)
+	}
+
+	wantNext := map[string]DeviceInfo{"BBB": devInfo("BBB"), "CCC": devInfo("CCC")}
+	if !reflect.DeepEqual(next, wantNext) {
+		t.Fatalf("diffDeviceSets() next = %#v, want %#v", next, wantNext)
+	}
+}
+
+func TestDiffDeviceSetsNoChange(t *testing.T) {
+	seen := map[string]DeviceInfo{"AAA": devInfo("AAA")}
+	current := []DeviceInfo{devInfo("AAA")}
+
+	events, next := diffDeviceSets(seen, current)
+	if len(events) != 0 {
+		t.Fatalf("expected no events, got %#v", events)
+	}
+	if !reflect.DeepEqual(next, seen) {
+		t.Fatalf("diffDeviceSets() next = %#v, want %#v", next, seen)
+	}
+}
+
+func TestDiffDeviceSetsIgnoresMissingSerial(t *testing.T) {
+	seen := map[string]DeviceInfo{}
+	current := []DeviceInfo{devInfo("")}
+
+	events, next := diffDeviceSets(seen, current)
+	if len(events) != 0 {
+		t.Fatalf("expected devices with empty serial to be ignored, got %#v", events)
+	}
+	if len(next) != 0 {
+		t.Fatalf("expected empty next snapshot, got %#v", next)
+	}
+}
+
+func TestManagerDetachOfUnknownSerialSkipsCallbacks(t *testing.T) {
+	var attached, detached []string
+
+	events := make(chan Event, 2)
+	m := &Manager{devices: make(map[string]Device), openFunc: NewWithSerial}
+	WithOnAttach(func(serial string, dev Device) { attached = append(attached, serial) })(m)
+	WithOnDetach(func(serial string, dev Device) { detached = append(detached, serial) })(m)
+
+	done := make(chan struct{})
+	go func() {
+		m.run(events)
+		close(done)
+	}()
+
+	events <- Event{Type: Detached, Serial: "does-not-exist"}
+	close(events)
+	<-done
+
+	if len(attached) != 0 || len(detached) != 0 {
+		t.Fatalf("expected no callbacks for an unknown serial, got attached=%v detached=%v", attached, detached)
+	}
+}
+
+func TestManagerAttachOpensDeviceAndInvokesCallback(t *testing.T) {
+	fake := &fakePowerDevice{}
+	var attachedSerial string
+	var attachedDev Device
+
+	events := make(chan Event, 2)
+	m := &Manager{
+		devices:  make(map[string]Device),
+		openFunc: func(serial string) (Device, error) { return fake, nil },
+	}
+	WithOnAttach(func(serial string, dev Device) {
+		attachedSerial, attachedDev = serial, dev
+	})(m)
+
+	done := make(chan struct{})
+	go func() {
+		m.run(events)
+		close(done)
+	}()
+
+	events <- Event{Type: Attached, Serial: "SERIAL1"}
+	close(events)
+	<-done
+
+	if attachedSerial != "SERIAL1" || attachedDev != Device(fake) {
+		t.Fatalf("onAttach called with (%q, %v), want (%q, %v)", attachedSerial, attachedDev, "SERIAL1", fake)
+	}
+	if got, ok := m.Get("SERIAL1"); !ok || got != Device(fake) {
+		t.Fatalf("Manager.Get(%q) = (%v, %v), want (%v, true)", "SERIAL1", got, ok, fake)
+	}
+}
+
+func TestManagerAttachSkipsDeviceOnOpenError(t *testing.T) {
+	var attachCalls int
+
+	events := make(chan Event, 2)
+	m := &Manager{
+		devices:  make(map[string]Device),
+		openFunc: func(serial string) (Device, error) { return nil, errors.New("open failed") },
+	}
+	WithOnAttach(func(serial string, dev Device) { attachCalls++ })(m)
+
+	done := make(chan struct{})
+	go func() {
+		m.run(events)
+		close(done)
+	}()
+
+	events <- Event{Type: Attached, Serial: "SERIAL1"}
+	close(events)
+	<-done
+
+	if attachCalls != 0 {
+		t.Fatalf("expected onAttach not to be called when openFunc fails, got %d calls", attachCalls)
+	}
+	if _, ok := m.Get("SERIAL1"); ok {
+		t.Fatalf("expected no device registered after a failed open")
+	}
+}
@@ -0,0 +1,271 @@
+// Package ykush provides a Go library for controlling Yepkit YKUSH USB
+// switching devices.
+//
+// The Yepkit YKUSH family (YKUSH, YKUSH3, YKUSHXS, and the original YKUSH
+// beta) are USB switches that allow you to programmatically turn USB ports
+// on and off. This is useful for power cycling USB devices, managing USB
+// device connections, or automating hardware testing scenarios.
+//
+// This package exposes a single Device interface and picks the right
+// implementation for you based on the USB product ID of the device it
+// opens, but only YKUSH3's command set is currently implemented: the other
+// models' opcodes and port counts reportedly differ from YKUSH3's, and
+// without a way to confirm them against real hardware, a Device for one of
+// them rejects every port operation with ErrUnsupportedModel rather than
+// guess. ListDevices and NewWithSerial still recognize and open all four
+// models.
+//
+// Basic usage:
+//
+//	// Connect to the first available YKUSH device of any model
+//	dev, err := ykush.New()
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	defer dev.Close()
+//
+//	// Turn on port 1
+//	err = dev.PortUp(ykush.Port1)
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//
+//	// Check port state
+//	state, err := dev.GetPortState(ykush.Port1)
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	fmt.Printf("Port 1 is %s\n", state)
+package ykush
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/sstallion/go-hid"
+)
+
+const (
+	// VendorID is the USB vendor ID used by all Yepkit YKUSH devices.
+	VendorID = 0x04D8
+	// ReportSize is the HID report size used for communication.
+	ReportSize = 64
+)
+
+// Model identifies a member of the YKUSH device family.
+type Model int
+
+const (
+	// ModelYKUSHBeta is the original YKUSH beta board. Its command set
+	// isn't implemented; see unsupportedDevice.
+	ModelYKUSHBeta Model = iota
+	// ModelYKUSH is the standard YKUSH switch. Its command set isn't
+	// implemented; see unsupportedDevice.
+	ModelYKUSH
+	// ModelYKUSH3 is the 3-port YKUSH3 switch, the only model whose
+	// command set this package currently implements.
+	ModelYKUSH3
+	// ModelYKUSHXS is the compact YKUSHXS switch. Its port count hasn't
+	// been confirmed (it may not be 3, unlike the other models in this
+	// family) and its command set isn't implemented; see
+	// unsupportedDevice.
+	ModelYKUSHXS
+)
+
+// String returns a human-readable name for the model.
+func (m Model) String() string {
+	switch m {
+	case ModelYKUSHBeta:
+		return "YKUSH (beta)"
+	case ModelYKUSH:
+		return "YKUSH"
+	case ModelYKUSH3:
+		return "YKUSH3"
+	case ModelYKUSHXS:
+		return "YKUSHXS"
+	default:
+		return fmt.Sprintf("unknown model (%d)", int(m))
+	}
+}
+
+// productIDs maps each supported model to its USB product ID.
+var productIDs = map[Model]uint16{
+	ModelYKUSHBeta: 0x0042,
+	ModelYKUSH:     0xF2F7,
+	ModelYKUSH3:    0xF11B,
+	ModelYKUSHXS:   0xF0CD,
+}
+
+// modelForProductID returns the Model corresponding to pid, and whether one
+// was found.
+func modelForProductID(pid uint16) (Model, bool) {
+	for model, p := range productIDs {
+		if p == pid {
+			return model, true
+		}
+	}
+	return 0, false
+}
+
+// Port represents a USB port number on a YKUSH device.
+type Port int
+
+const (
+	// Port1 is the first USB port.
+	Port1 Port = 1
+	// Port2 is the second USB port.
+	Port2 Port = 2
+	// Port3 is the third USB port.
+	Port3 Port = 3
+	// AllPorts represents all ports for bulk operations.
+	AllPorts Port = 10
+)
+
+// String returns a human-readable representation of the port.
+func (p Port) String() string {
+	switch p {
+	case Port1:
+		return "Port 1"
+	case Port2:
+		return "Port 2"
+	case Port3:
+		return "Port 3"
+	case AllPorts:
+		return "All Ports"
+	default:
+		return fmt.Sprintf("Port %d", int(p))
+	}
+}
+
+// PortState represents the on/off state of a USB port.
+type PortState bool
+
+const (
+	// PortOff indicates the port is turned off.
+	PortOff PortState = false
+	// PortOn indicates the port is turned on.
+	PortOn PortState = true
+)
+
+// String returns a human-readable representation of the port state.
+func (s PortState) String() string {
+	if s {
+		return "ON"
+	}
+	return "OFF"
+}
+
+// Device is implemented by every member of the YKUSH family. Callers that
+// don't care which exact model they're talking to can program against this
+// interface instead of a concrete type.
+type Device interface {
+	// PortUp turns on the specified USB port.
+	PortUp(port Port) error
+	// PortDown turns off the specified USB port.
+	PortDown(port Port) error
+	// GetPortState returns the current on/off state of the specified USB port.
+	GetPortState(port Port) (PortState, error)
+	// PortUpCtx is like PortUp but aborts with ctx.Err() if ctx is done
+	// before the device responds.
+	PortUpCtx(ctx context.Context, port Port) error
+	// PortDownCtx is like PortDown but aborts with ctx.Err() if ctx is
+	// done before the device responds.
+	PortDownCtx(ctx context.Context, port Port) error
+	// GetPortStateCtx is like GetPortState but aborts with ctx.Err() if
+	// ctx is done before the device responds.
+	GetPortStateCtx(ctx context.Context, port Port) (PortState, error)
+	// Ports returns the set of ports this device exposes.
+	Ports() []Port
+	// Model returns the human-readable name of the device model.
+	Model() string
+	// Close closes the connection to the device and releases resources.
+	Close() error
+}
+
+// DeviceInfo describes a YKUSH device discovered on the system.
+type DeviceInfo struct {
+	hid.DeviceInfo
+	Model Model
+}
+
+// New creates a new Device and opens the first available YKUSH device of
+// any supported model.
+func New() (Device, error) {
+	return NewWithSerial("")
+}
+
+// NewWithSerial creates a new Device and opens the device with the
+// specified serial number. If serial is empty, it opens the first
+// available device of any supported model.
+func NewWithSerial(serial string) (Device, error) {
+	if err := hid.Init(); err != nil {
+		return nil, fmt.Errorf("failed to initialize HID library: %w", err)
+	}
+
+	var match *hid.DeviceInfo
+	err := hid.Enumerate(VendorID, 0, func(info *hid.DeviceInfo) error {
+		if match != nil {
+			return nil
+		}
+		if _, ok := modelForProductID(info.ProductID); !ok {
+			return nil
+		}
+		if serial != "" && info.SerialNbr != serial {
+			return nil
+		}
+		infoCopy := *info
+		match = &infoCopy
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to enumerate devices: %w", err)
+	}
+	if match == nil {
+		return nil, fmt.Errorf("no YKUSH device found")
+	}
+
+	model, _ := modelForProductID(match.ProductID)
+	device, err := hid.OpenPath(match.Path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open YKUSH device: %w", err)
+	}
+
+	return newDevice(model, device), nil
+}
+
+// newDevice wraps an opened hid.Device in the implementation appropriate
+// for model. Only ModelYKUSH3's command set is currently implemented; every
+// other model gets an unsupportedDevice that can be identified and closed
+// but rejects every port operation. See the doc comment on
+// unsupportedDevice for why.
+func newDevice(model Model, device *hid.Device) Device {
+	switch model {
+	case ModelYKUSH3:
+		return &YKUSH3{conn: conn{device: device}}
+	default:
+		return &unsupportedDevice{conn: conn{device: device}, model: model}
+	}
+}
+
+// ListDevices returns information about all connected YKUSH devices of any
+// supported model.
+func ListDevices() ([]DeviceInfo, error) {
+	if err := hid.Init(); err != nil {
+		return nil, fmt.Errorf("failed to initialize HID library: %w", err)
+	}
+
+	var devices []DeviceInfo
+	err := hid.Enumerate(VendorID, 0, func(info *hid.DeviceInfo) error {
+		model, ok := modelForProductID(info.ProductID)
+		if !ok {
+			return nil
+		}
+		devices = append(devices, DeviceInfo{DeviceInfo: *info, Model: model})
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to enumerate devices: %w", err)
+	}
+
+	return devices, nil
+}